@@ -0,0 +1,60 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// ComparisonOperator represents a binary comparison operator.
+type ComparisonOperator int
+
+// ComparisonOperator values.
+const (
+	EQ ComparisonOperator = iota
+	In
+)
+
+func (op ComparisonOperator) String() string {
+	switch op {
+	case EQ:
+		return "="
+	case In:
+		return "IN"
+	default:
+		return "?"
+	}
+}
+
+// ComparisonExpr represents a two-operand comparison, such as `a = b` or
+// `a IN (1, 2, 3)`.
+type ComparisonExpr struct {
+	Operator ComparisonOperator
+	Left     Expr
+	Right    Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ComparisonExpr) Format(buf *bytes.Buffer, f FmtFlags) {
+	FormatNode(buf, f, node.Left)
+	buf.WriteByte(' ')
+	buf.WriteString(node.Operator.String())
+	buf.WriteByte(' ')
+	if node.Operator == In {
+		if tuple, ok := node.Right.(*Tuple); ok {
+			tuple.formatAsInList(buf, f)
+			return
+		}
+	}
+	FormatNode(buf, f, node.Right)
+}
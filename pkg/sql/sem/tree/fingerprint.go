@@ -0,0 +1,55 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"hash/fnv"
+)
+
+// FmtFingerprint composes FmtHideConstants -- which replaces literals and
+// array elements with `_` -- with the additional canonicalization needed
+// to group semantically equivalent statements for telemetry and
+// slow-query dashboards: IN-list elements are sorted, so `IN (1,2,3)` and
+// `IN (3,1,2)` agree, and uniform VALUES tuples are collapsed, so bulk
+// inserts of varying arity agree too, while non-uniform VALUES tuples are
+// sorted. Keywords are already emitted in
+// canonical case and punctuation in normalized form by every Format
+// method in this package, so no further whitespace handling is needed
+// here.
+var FmtFingerprint = func() FmtFlags {
+	f := FmtHideConstants
+	f.sortInLists = true
+	f.collapseValuesTuples = true
+	return f
+}()
+
+// Fingerprint renders stmt into a canonical, cross-version-stable SQL
+// string and returns that string alongside a 64-bit FNV-1a hash of it.
+// Two statements that differ only in their literal constants, in
+// whitespace or keyword casing, or in the ordering of an IN list or the
+// tuples of a VALUES clause, produce the same canonical string and
+// therefore the same hash.
+//
+// The canonical string is built once into buf, and the hash is computed
+// by writing buf's bytes directly into the FNV sum as soon as formatting
+// completes, rather than re-rendering stmt a second time to compute it.
+func Fingerprint(stmt NodeFormatter) (canonicalSQL string, hash uint64) {
+	var buf bytes.Buffer
+	FormatNode(&buf, FmtFingerprint, stmt)
+	h := fnv.New64a()
+	h.Write(buf.Bytes())
+	return buf.String(), h.Sum64()
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// FmtFlags carries options for the pretty-printer used throughout this
+// package, from AsStringWithFlags down to every NodeFormatter.Format
+// method.
+//
+// FmtFlags is a struct rather than a plain bitmask because some formatting
+// behaviors need to carry additional state alongside the on/off switches.
+// Most such state is expressed through ctx, a FormatContext of per-node-
+// type overrides installed by RegisterFormatOverride; see FmtReformatTableNames
+// below, and FmtHideConstants and FmtSimpleWithPasswords in hide_constants.go,
+// for examples.
+type FmtFlags struct {
+	bareStrings     bool
+	bareIdentifiers bool
+
+	// sortInLists and collapseValuesTuples are used by FmtFingerprint to
+	// normalize away orderings and repetitions that do not change the
+	// semantics of a statement. See fingerprint.go.
+	sortInLists          bool
+	collapseValuesTuples bool
+
+	// ctx, when non-nil, carries per-AST-node-type formatting overrides
+	// installed by RegisterFormatOverride.
+	ctx *FormatContext
+}
+
+// FormatContext carries a set of per-AST-node-type formatting overrides.
+// It lets a caller plug in a rendering for the concrete node types it
+// cares about -- a placeholder, a string literal, a particular function
+// call -- without extending FmtFlags (and therefore the tree package)
+// every time a new cross-cutting formatting behavior is needed.
+type FormatContext struct {
+	overrides map[reflect.Type]func(NodeFormatter, *bytes.Buffer, FmtFlags)
+}
+
+// RegisterFormatOverride returns a copy of f in which every node whose
+// concrete type matches that of sample is rendered by calling fn instead
+// of its own Format method. sample is typically a nil pointer of the
+// desired type, e.g. (*Placeholder)(nil).
+//
+// Overrides compose: registering an override for *StrVal on top of flags
+// that already override *Placeholder keeps both.
+func RegisterFormatOverride(
+	f FmtFlags, sample NodeFormatter, fn func(NodeFormatter, *bytes.Buffer, FmtFlags),
+) FmtFlags {
+	overrides := make(map[reflect.Type]func(NodeFormatter, *bytes.Buffer, FmtFlags))
+	if f.ctx != nil {
+		for typ, existingFn := range f.ctx.overrides {
+			overrides[typ] = existingFn
+		}
+	}
+	overrides[reflect.TypeOf(sample)] = fn
+	f.ctx = &FormatContext{overrides: overrides}
+	return f
+}
+
+var (
+	// FmtSimple formats a node with default settings: qualified names are
+	// printed in full and passwords are redacted.
+	FmtSimple = FmtFlags{}
+
+	// FmtBareStrings instructs the pretty-printer to print strings without
+	// wrapping quotes if the string requires no escaping.
+	FmtBareStrings = FmtFlags{bareStrings: true}
+
+	// FmtBareIdentifiers instructs the pretty-printer to print identifiers
+	// without wrapping quotes in all cases.
+	FmtBareIdentifiers = FmtFlags{bareIdentifiers: true}
+)
+
+// NodeFormatter is implemented by every AST node that can be
+// pretty-printed.
+type NodeFormatter interface {
+	// Format appends a pretty-printed representation of the node to buf,
+	// honoring flags.
+	Format(buf *bytes.Buffer, flags FmtFlags)
+}
+
+// FormatNode formats n into buf according to f. It is the single
+// choke point that every Format method should call on its children,
+// rather than invoking child.Format directly: besides honoring
+// FmtFlags's own settings, it is where a registered format override for
+// n's concrete type (see RegisterFormatOverride) gets a chance to run
+// instead of n's default rendering.
+func FormatNode(buf *bytes.Buffer, f FmtFlags, n NodeFormatter) {
+	if f.ctx != nil {
+		if fn, ok := f.ctx.overrides[reflect.TypeOf(n)]; ok {
+			fn(n, buf, f)
+			return
+		}
+	}
+	n.Format(buf, f)
+}
+
+// AsStringWithFlags pretty-prints n using the given flags.
+func AsStringWithFlags(n NodeFormatter, f FmtFlags) string {
+	var buf bytes.Buffer
+	FormatNode(&buf, f, n)
+	return buf.String()
+}
+
+// AsString pretty-prints n using FmtSimple.
+func AsString(n NodeFormatter) string {
+	return AsStringWithFlags(n, FmtSimple)
+}
+
+// FmtReformatTableNames returns a copy of f that renders every table name
+// encountered while formatting a statement by calling fn instead of using
+// the default rendering. Every statement that carries table references --
+// CREATE/INSERT/UPDATE/DELETE/ALTER/SHOW, TRUNCATE, GRANT/REVOKE -- routes
+// its table names through *NormalizableTableName, so overriding that one
+// type rewrites all of them uniformly.
+func FmtReformatTableNames(
+	f FmtFlags, fn func(*NormalizableTableName, *bytes.Buffer, FmtFlags),
+) FmtFlags {
+	return RegisterFormatOverride(f, (*NormalizableTableName)(nil),
+		func(n NodeFormatter, buf *bytes.Buffer, f FmtFlags) {
+			fn(n.(*NormalizableTableName), buf, f)
+		})
+}
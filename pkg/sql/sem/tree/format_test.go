@@ -55,13 +55,10 @@ func TestFormatStatement(t *testing.T) {
 			`SHOW COLUMNS FROM xoxoxo`},
 		{`SHOW CREATE TABLE foo`, tableFormatter,
 			`SHOW CREATE TABLE xoxoxo`},
-		// TODO(knz): TRUNCATE and GRANT table names are removed by
-		// tree.FmtAnonymize but not processed by table formatters.
-		//
-		// {`TRUNCATE foo`, tableFormatter,
-		// `TRUNCATE TABLE xoxoxo`},
-		// {`GRANT SELECT ON bar TO foo`, tableFormatter,
-		// `GRANT SELECT ON xoxoxo TO foo`},
+		{`TRUNCATE foo`, tableFormatter,
+			`TRUNCATE TABLE xoxoxo`},
+		{`GRANT SELECT ON bar TO foo`, tableFormatter,
+			`GRANT SELECT ON xoxoxo TO foo`},
 
 		{`CREATE TABLE foo (x INT)`, tree.FmtAnonymize,
 			`CREATE TABLE _ (_ INT)`},
@@ -210,3 +207,103 @@ func TestFormatExpr(t *testing.T) {
 		})
 	}
 }
+
+// TestFingerprint checks that tree.Fingerprint assigns the same canonical
+// string and hash to statements that differ only in their literal
+// constants, in the ordering of an IN list, or in the number of uniform
+// tuples of a VALUES clause.
+func TestFingerprint(t *testing.T) {
+	inWithOrder := func(vals ...string) *tree.ComparisonExpr {
+		exprs := make([]tree.Expr, len(vals))
+		for i, v := range vals {
+			exprs[i] = &tree.NumVal{Value: v}
+		}
+		return &tree.ComparisonExpr{
+			Operator: tree.In,
+			Left:     &tree.StrVal{Value: "x"},
+			Right:    &tree.Tuple{Exprs: exprs},
+		}
+	}
+
+	a, aHash := tree.Fingerprint(inWithOrder("1", "2", "3"))
+	b, bHash := tree.Fingerprint(inWithOrder("3", "1", "2"))
+	if a != b || aHash != bHash {
+		t.Fatalf("expected IN (1,2,3) and IN (3,1,2) to fingerprint identically, got %q/%d vs %q/%d", a, aHash, b, bHash)
+	}
+
+	// inWithOrder above hides every element behind FmtHideConstants, so it
+	// would pass even with sortInLists deleted entirely: all the elements
+	// render as `_` regardless of order. Exercise the sort itself with
+	// non-constant operands (column references), which FmtFingerprint does
+	// not hide and which genuinely differ unless sorted.
+	identIn := func(vals ...string) *tree.ComparisonExpr {
+		exprs := make([]tree.Expr, len(vals))
+		for i, v := range vals {
+			exprs[i] = tree.Name(v)
+		}
+		return &tree.ComparisonExpr{
+			Operator: tree.In,
+			Left:     tree.Name("x"),
+			Right:    &tree.Tuple{Exprs: exprs},
+		}
+	}
+
+	e, eHash := tree.Fingerprint(identIn("a", "b", "c"))
+	g, gHash := tree.Fingerprint(identIn("c", "a", "b"))
+	if e != g || eHash != gHash {
+		t.Fatalf("expected IN (a,b,c) and IN (c,a,b) to fingerprint identically, got %q/%d vs %q/%d", e, eHash, g, gHash)
+	}
+	if e != "x IN (a, b, c)" {
+		t.Fatalf("unexpected canonical form: %q", e)
+	}
+
+	tuple := func(v string) *tree.Tuple {
+		return &tree.Tuple{Exprs: []tree.Expr{&tree.NumVal{Value: v}, &tree.NumVal{Value: v}}}
+	}
+	two := &tree.ValuesClause{Rows: []*tree.Tuple{tuple("1"), tuple("2")}}
+	three := &tree.ValuesClause{Rows: []*tree.Tuple{tuple("1"), tuple("2"), tuple("3")}}
+
+	c, cHash := tree.Fingerprint(two)
+	d, dHash := tree.Fingerprint(three)
+	if c != d || cHash != dHash {
+		t.Fatalf("expected VALUES clauses of varying arity but uniform shape to fingerprint identically, got %q/%d vs %q/%d", c, cHash, d, dHash)
+	}
+	if c != "VALUES (_, _), ..." {
+		t.Fatalf("unexpected canonical form: %q", c)
+	}
+}
+
+// TestRegisterFormatOverride demonstrates a caller plugging in a
+// formatting override for a single AST node type -- redacting only the
+// arguments of calls to crdb_internal.encrypt, leaving every other
+// function call untouched -- without the tree package knowing anything
+// about that behavior ahead of time.
+func TestRegisterFormatOverride(t *testing.T) {
+	redactEncryptArgs := tree.RegisterFormatOverride(tree.FmtSimple, (*tree.FuncExpr)(nil),
+		func(n tree.NodeFormatter, buf *bytes.Buffer, f tree.FmtFlags) {
+			fn := n.(*tree.FuncExpr)
+			if fn.Func != "crdb_internal.encrypt" {
+				fn.Format(buf, f)
+				return
+			}
+			buf.WriteString(fn.Func)
+			buf.WriteByte('(')
+			for i := range fn.Exprs {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString("'redacted'")
+			}
+			buf.WriteByte(')')
+		})
+
+	encrypt := &tree.FuncExpr{Func: "crdb_internal.encrypt", Exprs: []tree.Expr{&tree.StrVal{Value: "secret"}}}
+	other := &tree.FuncExpr{Func: "length", Exprs: []tree.Expr{&tree.StrVal{Value: "secret"}}}
+
+	if got, want := tree.AsStringWithFlags(encrypt, redactEncryptArgs), `crdb_internal.encrypt('redacted')`; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got, want := tree.AsStringWithFlags(other, redactEncryptArgs), `length('secret')`; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
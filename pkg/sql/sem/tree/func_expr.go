@@ -0,0 +1,36 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// FuncExpr represents a function call, such as `crdb_internal.encrypt(x)`.
+type FuncExpr struct {
+	Func  string
+	Exprs []Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *FuncExpr) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString(node.Func)
+	buf.WriteByte('(')
+	for i, e := range node.Exprs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		FormatNode(buf, f, e)
+	}
+	buf.WriteByte(')')
+}
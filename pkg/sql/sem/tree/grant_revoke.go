@@ -0,0 +1,87 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// TargetList represents the set of targets of a GRANT or REVOKE statement.
+// Only table targets are modeled here; TargetList has no field for
+// database-level targets.
+type TargetList struct {
+	Tables TableNameReferences
+}
+
+// Format implements the NodeFormatter interface.
+func (tl *TargetList) Format(buf *bytes.Buffer, f FmtFlags) {
+	FormatNode(buf, f, &tl.Tables)
+}
+
+// Privilege represents a single privilege keyword in a GRANT or REVOKE
+// statement, such as SELECT or ALL. Unlike Name, it names a fixed SQL
+// keyword rather than a user-chosen identifier, so it is never quoted
+// and is not subject to FmtAnonymize.
+type Privilege string
+
+// Format implements the NodeFormatter interface.
+func (p Privilege) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString(string(p))
+}
+
+// PrivilegeList is a list of Privileges.
+type PrivilegeList []Privilege
+
+// Format implements the NodeFormatter interface.
+func (l PrivilegeList) Format(buf *bytes.Buffer, f FmtFlags) {
+	for i, p := range l {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		FormatNode(buf, f, p)
+	}
+}
+
+// Grant represents a GRANT statement.
+type Grant struct {
+	Privileges PrivilegeList
+	Targets    TargetList
+	Grantees   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Grant) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString("GRANT ")
+	FormatNode(buf, f, node.Privileges)
+	buf.WriteString(" ON ")
+	FormatNode(buf, f, &node.Targets)
+	buf.WriteString(" TO ")
+	FormatNode(buf, f, node.Grantees)
+}
+
+// Revoke represents a REVOKE statement.
+type Revoke struct {
+	Privileges PrivilegeList
+	Targets    TargetList
+	Grantees   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Revoke) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString("REVOKE ")
+	FormatNode(buf, f, node.Privileges)
+	buf.WriteString(" ON ")
+	FormatNode(buf, f, &node.Targets)
+	buf.WriteString(" FROM ")
+	FormatNode(buf, f, node.Grantees)
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// hidePlaceholder renders any overridden node as a single `_`. It backs
+// FmtHideConstants below.
+func hidePlaceholder(_ NodeFormatter, buf *bytes.Buffer, _ FmtFlags) {
+	buf.WriteByte('_')
+}
+
+// FmtHideConstants instructs the pretty-printer to replace literal
+// constants (strings, numbers) with an underscore placeholder, so that
+// statements that differ only in their constants format identically. It
+// is implemented on top of RegisterFormatOverride, as a proof point for
+// that mechanism: it simply overrides *NumVal and *StrVal with
+// hidePlaceholder.
+var FmtHideConstants = RegisterFormatOverride(
+	RegisterFormatOverride(FmtSimple, (*NumVal)(nil), hidePlaceholder),
+	(*StrVal)(nil), hidePlaceholder,
+)
+
+// FmtAnonymize instructs the pretty-printer to additionally strip every
+// identifiable name (table names, grantees, column names, etc.) from the
+// output, on top of hiding constants like FmtHideConstants. Table names
+// and other identifiers are overridden with hidePlaceholder the same way
+// FmtHideConstants overrides literals above; privilege keywords (see
+// Privilege in grant_revoke.go) are deliberately left alone since they are
+// grammar, not user-chosen names.
+var FmtAnonymize = RegisterFormatOverride(
+	RegisterFormatOverride(FmtHideConstants, (*NormalizableTableName)(nil), hidePlaceholder),
+	Name(""), hidePlaceholder,
+)
+
+// Password represents a password literal appearing in CREATE/ALTER USER
+// and similar statements. By default it is redacted; FmtSimpleWithPasswords
+// reveals it.
+type Password StrVal
+
+// Format implements the NodeFormatter interface. FmtSimpleWithPasswords
+// overrides this with a rendering that reveals Value.
+func (p *Password) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString("*****")
+}
+
+// FmtSimpleWithPasswords is like FmtSimple but does not redact passwords.
+// It is implemented on top of RegisterFormatOverride, as a proof point for
+// that mechanism: it overrides *Password to reveal its value instead of
+// printing asterisks.
+var FmtSimpleWithPasswords = RegisterFormatOverride(FmtSimple, (*Password)(nil),
+	func(n NodeFormatter, buf *bytes.Buffer, f FmtFlags) {
+		encodeSQLString(buf, n.(*Password).Value)
+	})
@@ -0,0 +1,88 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Expr is implemented by every expression AST node.
+type Expr interface {
+	NodeFormatter
+}
+
+// NumVal represents a numeric literal, before it has been typed as an
+// int, float or decimal.
+type NumVal struct {
+	// Value is the literal as it appeared in the original SQL text, e.g.
+	// "123" or "3.14".
+	Value string
+}
+
+// Format implements the NodeFormatter interface. FmtHideConstants
+// overrides this with a rendering that writes a placeholder instead of
+// Value.
+func (n *NumVal) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString(n.Value)
+}
+
+// StrVal represents a string literal.
+type StrVal struct {
+	Value string
+}
+
+// Format implements the NodeFormatter interface. FmtHideConstants
+// overrides this with a rendering that writes a placeholder instead of
+// Value.
+func (v *StrVal) Format(buf *bytes.Buffer, f FmtFlags) {
+	if f.bareStrings && isBareString(v.Value) {
+		buf.WriteString(v.Value)
+		return
+	}
+	encodeSQLString(buf, v.Value)
+}
+
+func isBareString(s string) bool {
+	for _, c := range s {
+		switch c {
+		case ' ', ',', '{', '}', '\'':
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+func encodeSQLString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('\'')
+	for _, c := range s {
+		if c == '\'' {
+			buf.WriteByte('\'')
+		}
+		buf.WriteRune(c)
+	}
+	buf.WriteByte('\'')
+}
+
+// Placeholder represents a positional query placeholder, e.g. $1.
+type Placeholder struct {
+	Idx int
+}
+
+// Format implements the NodeFormatter interface.
+func (p *Placeholder) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteByte('$')
+	buf.WriteString(strconv.Itoa(p.Idx))
+}
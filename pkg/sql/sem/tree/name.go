@@ -0,0 +1,67 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// Name is an SQL identifier, such as a table, column or database name.
+type Name string
+
+// Format implements the NodeFormatter interface.
+func (n Name) Format(buf *bytes.Buffer, f FmtFlags) {
+	if f.bareIdentifiers {
+		buf.WriteString(string(n))
+		return
+	}
+	encodeSQLIdent(buf, string(n))
+}
+
+// NameList is a list of Names.
+type NameList []Name
+
+// Format implements the NodeFormatter interface.
+func (l NameList) Format(buf *bytes.Buffer, f FmtFlags) {
+	for i, n := range l {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		FormatNode(buf, f, n)
+	}
+}
+
+// encodeSQLIdent writes ident to buf, double-quoting it if it is not a
+// bare lowercase identifier.
+func encodeSQLIdent(buf *bytes.Buffer, ident string) {
+	bareOK := len(ident) > 0
+	for i, c := range ident {
+		if c >= 'a' && c <= 'z' || c == '_' || (i > 0 && c >= '0' && c <= '9') {
+			continue
+		}
+		bareOK = false
+		break
+	}
+	if bareOK {
+		buf.WriteString(ident)
+		return
+	}
+	buf.WriteByte('"')
+	for _, c := range ident {
+		if c == '"' {
+			buf.WriteByte('"')
+		}
+		buf.WriteRune(c)
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// TableExpr is implemented by node types that can appear wherever a table
+// name or table-valued expression is expected (FROM clauses, INSERT
+// targets, etc).
+type TableExpr interface {
+	NodeFormatter
+	tableExpr()
+}
+
+// TableName corresponds to the name of a table, optionally qualified by
+// its database, as it appears in a FROM clause, INSERT, UPDATE, TRUNCATE,
+// GRANT, etc.
+type TableName struct {
+	DatabaseName Name
+	TableName    Name
+}
+
+func (*TableName) tableExpr() {}
+
+// Format implements the NodeFormatter interface.
+func (t *TableName) Format(buf *bytes.Buffer, f FmtFlags) {
+	if t.DatabaseName != "" {
+		FormatNode(buf, f, t.DatabaseName)
+		buf.WriteByte('.')
+	}
+	FormatNode(buf, f, t.TableName)
+}
+
+// NormalizableTableName wraps a table name reference that may or may not
+// have been normalized into a fully qualified TableName yet.
+//
+// This is the type through which every table reference in a statement is
+// formatted: CREATE/INSERT/UPDATE/DELETE/ALTER/SHOW, TRUNCATE and
+// GRANT/REVOKE all hold their table references as (lists of)
+// *NormalizableTableName, so FmtReformatTableNames only needs to special-
+// case this one Format method to rewrite every table name uniformly.
+type NormalizableTableName struct {
+	TableNameReference TableExpr
+}
+
+// Format implements the NodeFormatter interface. It is the single choke
+// point through which every table name in a statement passes, which is
+// what lets FmtReformatTableNames rewrite all of them uniformly by
+// overriding this one type.
+func (n *NormalizableTableName) Format(buf *bytes.Buffer, f FmtFlags) {
+	FormatNode(buf, f, n.TableNameReference)
+}
+
+// TableNameReferences represents a comma-separated list of table names,
+// such as the target of a TRUNCATE or the target list of a GRANT/REVOKE.
+type TableNameReferences []*NormalizableTableName
+
+// Format implements the NodeFormatter interface.
+func (node *TableNameReferences) Format(buf *bytes.Buffer, f FmtFlags) {
+	for i, n := range *node {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		FormatNode(buf, f, n)
+	}
+}
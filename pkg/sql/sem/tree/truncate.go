@@ -0,0 +1,46 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import "bytes"
+
+// DropBehavior represents the CASCADE/RESTRICT qualifier of a DROP-like
+// statement.
+type DropBehavior int
+
+// DropBehavior values.
+const (
+	DropDefault DropBehavior = iota
+	DropRestrict
+	DropCascade
+)
+
+// TruncateStmt represents a TRUNCATE statement.
+type TruncateStmt struct {
+	Names        TableNameReferences
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TruncateStmt) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString("TRUNCATE TABLE ")
+	FormatNode(buf, f, &node.Names)
+	switch node.DropBehavior {
+	case DropCascade:
+		buf.WriteString(" CASCADE")
+	case DropRestrict:
+		buf.WriteString(" RESTRICT")
+	}
+}
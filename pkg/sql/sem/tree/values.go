@@ -0,0 +1,109 @@
+// Copyright 2017 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Tuple represents a parenthesized list of expressions, such as the
+// right-hand side of an IN predicate or a single row of a VALUES clause.
+type Tuple struct {
+	Exprs []Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Tuple) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteByte('(')
+	for i, e := range node.Exprs {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		FormatNode(buf, f, e)
+	}
+	buf.WriteByte(')')
+}
+
+// formatAsInList renders the tuple as the right-hand operand of an IN
+// comparison. When f.sortInLists is set, the elements are sorted by their
+// formatted representation first, so that `IN (1, 2, 3)` and `IN (3, 1, 2)`
+// render identically regardless of the order they were written in.
+func (node *Tuple) formatAsInList(buf *bytes.Buffer, f FmtFlags) {
+	if !f.sortInLists || (f.ctx != nil && f.ctx.overrides[reflect.TypeOf(node)] != nil) {
+		FormatNode(buf, f, node)
+		return
+	}
+	parts := make([]string, len(node.Exprs))
+	for i, e := range node.Exprs {
+		parts[i] = AsStringWithFlags(e, f)
+	}
+	sort.Strings(parts)
+	buf.WriteByte('(')
+	buf.WriteString(strings.Join(parts, ", "))
+	buf.WriteByte(')')
+}
+
+// ValuesClause represents a VALUES clause, as used by INSERT and as a
+// standalone statement.
+type ValuesClause struct {
+	Rows []*Tuple
+}
+
+// Format implements the NodeFormatter interface. When
+// f.collapseValuesTuples is set, rows that all render identically are
+// collapsed to the first row followed by `, ...`; otherwise the rows are
+// sorted by their formatted representation.
+func (node *ValuesClause) Format(buf *bytes.Buffer, f FmtFlags) {
+	buf.WriteString("VALUES ")
+	if f.collapseValuesTuples {
+		if node.allRowsEquivalent(f) {
+			FormatNode(buf, f, node.Rows[0])
+			buf.WriteString(", ...")
+			return
+		}
+		parts := make([]string, len(node.Rows))
+		for i, row := range node.Rows {
+			parts[i] = AsStringWithFlags(row, f)
+		}
+		sort.Strings(parts)
+		buf.WriteString(strings.Join(parts, ", "))
+		return
+	}
+	for i, row := range node.Rows {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		FormatNode(buf, f, row)
+	}
+}
+
+// allRowsEquivalent reports whether every row renders identically under f,
+// which lets a bulk INSERT with any number of value tuples collapse to the
+// same fingerprint as long as the tuples agree once constants are hidden.
+func (node *ValuesClause) allRowsEquivalent(f FmtFlags) bool {
+	if len(node.Rows) < 2 {
+		return false
+	}
+	first := AsStringWithFlags(node.Rows[0], f)
+	for _, row := range node.Rows[1:] {
+		if AsStringWithFlags(row, f) != first {
+			return false
+		}
+	}
+	return true
+}